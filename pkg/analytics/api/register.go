@@ -0,0 +1,12 @@
+package api
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+)
+
+func init() {
+	kapi.Scheme.AddKnownTypes(SchemeGroupVersion,
+		&AnalyticsPolicy{},
+		&AnalyticsPolicyList{},
+	)
+}
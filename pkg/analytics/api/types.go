@@ -0,0 +1,53 @@
+package api
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// SchemeGroupVersion is the group version handled by this package's types.
+var SchemeGroupVersion = unversioned.GroupVersion{Group: "analytics.openshift.io", Version: "v1"}
+
+// AnalyticsPolicy lets a cluster admin declare, without redeploying ThirdPartyAnalyticsController,
+// which objects get reported to which analytics backend. The controller watches AnalyticsPolicy
+// objects and re-evaluates Spec.Rules against every event it would otherwise track.
+type AnalyticsPolicy struct {
+	unversioned.TypeMeta `json:",inline"`
+	kapi.ObjectMeta      `json:"metadata,omitempty"`
+
+	Spec AnalyticsPolicySpec `json:"spec"`
+}
+
+// AnalyticsPolicySpec is the desired tracking configuration described by an AnalyticsPolicy.
+type AnalyticsPolicySpec struct {
+	// Rules are evaluated independently; an event is reported to the BackendRef of every rule
+	// that matches it. An event matching no rule is not tracked at all.
+	Rules []AnalyticsPolicyRule `json:"rules"`
+}
+
+// AnalyticsPolicyRule matches events by resource kind, verb, and object/namespace labels, and
+// names the backend such matching events should be reported to.
+type AnalyticsPolicyRule struct {
+	// Resource is the short resource name the controller enqueues events under, e.g. "pod",
+	// "route", "build". Required.
+	Resource string `json:"resource"`
+	// Verbs restricts the rule to "add", "update", and/or "delete" events. Empty matches all.
+	Verbs []string `json:"verbs,omitempty"`
+	// NamespaceSelector restricts the rule to namespaces whose labels match. Nil matches any
+	// namespace.
+	NamespaceSelector *unversioned.LabelSelector `json:"namespaceSelector,omitempty"`
+	// LabelSelector restricts the rule to objects whose own labels match. Nil matches any
+	// object.
+	LabelSelector *unversioned.LabelSelector `json:"labelSelector,omitempty"`
+	// BackendRef names the BackendConfig (by BackendConfig.Name) events matching this rule are
+	// sent to.
+	BackendRef string `json:"backendRef"`
+}
+
+// AnalyticsPolicyList is a list of AnalyticsPolicy objects.
+type AnalyticsPolicyList struct {
+	unversioned.TypeMeta `json:",inline"`
+	unversioned.ListMeta `json:"metadata,omitempty"`
+
+	Items []AnalyticsPolicy `json:"items"`
+}
@@ -0,0 +1,60 @@
+// Package cloudevents encodes analytics tracking events as CloudEvents v1.0 JSON envelopes, so
+// a downstream consumer gets the object's identity (UID, resourceVersion) and a "when" rather
+// than the flat, identity-less query string the legacy Woopra tracker sends.
+//
+// See https://github.com/cloudevents/spec/blob/v1.0/spec.md for the envelope fields.
+package cloudevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const specVersion = "1.0"
+
+// Event is a CloudEvents v1.0 JSON envelope.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	Type            string          `json:"type"`
+	Source          string          `json:"source"`
+	ID              string          `json:"id"`
+	Time            string          `json:"time"`
+	Subject         string          `json:"subject"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// ObjectContext is the redacted subset of an object's metadata carried as an Event's Data.
+type ObjectContext struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	UID             string `json:"uid"`
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// NewEvent builds a CloudEvents v1.0 envelope for one tracked object change. kind and action
+// compose Type as "com.openshift.<kind>.<action>"; source identifies the cluster the event came
+// from; ID is derived from the object's UID and resourceVersion so redelivering the same
+// resourceVersion produces the same event ID.
+func NewEvent(kind, action, source string, obj ObjectContext, when time.Time) (Event, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		SpecVersion:     specVersion,
+		Type:            fmt.Sprintf("com.openshift.%s.%s", kind, action),
+		Source:          source,
+		ID:              fmt.Sprintf("%s/%s", obj.UID, obj.ResourceVersion),
+		Time:            when.UTC().Format(time.RFC3339Nano),
+		Subject:         fmt.Sprintf("%s/%s", obj.Namespace, obj.Name),
+		DataContentType: "application/json",
+		Data:            data,
+	}, nil
+}
+
+// Marshal encodes e as JSON, ready to POST as an HTTP request body.
+func Marshal(e Event) ([]byte, error) {
+	return json.Marshal(e)
+}
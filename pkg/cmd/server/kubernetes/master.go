@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"net"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/emicklei/go-restful"
 	"github.com/golang/glog"
@@ -15,19 +17,27 @@ import (
 	"k8s.io/kubernetes/pkg/api/v1"
 	extv1beta1 "k8s.io/kubernetes/pkg/apis/extensions/v1beta1"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/client/leaderelection"
+	"k8s.io/kubernetes/pkg/client/leaderelection/resourcelock"
 	"k8s.io/kubernetes/pkg/client/record"
+	restclient "k8s.io/kubernetes/pkg/client/restclient"
+	"k8s.io/kubernetes/pkg/client/typed/discovery"
+	"k8s.io/kubernetes/pkg/client/typed/dynamic"
 	client "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/controller"
 	"k8s.io/kubernetes/pkg/controller/daemon"
 	endpointcontroller "k8s.io/kubernetes/pkg/controller/endpoint"
+	"k8s.io/kubernetes/pkg/controller/garbagecollector"
 	jobcontroller "k8s.io/kubernetes/pkg/controller/job"
 	namespacecontroller "k8s.io/kubernetes/pkg/controller/namespace"
 	nodecontroller "k8s.io/kubernetes/pkg/controller/node"
 	volumeclaimbinder "k8s.io/kubernetes/pkg/controller/persistentvolume"
 	podautoscalercontroller "k8s.io/kubernetes/pkg/controller/podautoscaler"
 	"k8s.io/kubernetes/pkg/controller/podautoscaler/metrics"
+	gccontroller "k8s.io/kubernetes/pkg/controller/podgc"
 	replicationcontroller "k8s.io/kubernetes/pkg/controller/replication"
 	kresourcequota "k8s.io/kubernetes/pkg/controller/resourcequota"
+	attachdetachcontroller "k8s.io/kubernetes/pkg/controller/volume/attachdetach"
 	"k8s.io/kubernetes/pkg/master"
 	quotainstall "k8s.io/kubernetes/pkg/quota/install"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -81,6 +91,48 @@ func (c *MasterConfig) InstallAPI(container *restful.Container) ([]string, error
 	return messages, nil
 }
 
+// runLeaderElected runs fn only on the elected leader among however many Origin master
+// processes are running concurrently, using an endpoints-based resource lock in the
+// kube-system namespace. Without this, every master's "go controller.Run(...)" call fires
+// unconditionally and active/active masters double-reconcile every object. When leader
+// election is disabled, fn just runs immediately, matching the historical behavior.
+func (c *MasterConfig) runLeaderElected(name string, fn func(stopCh <-chan struct{})) {
+	le := c.ControllerManager.LeaderElection
+	if !le.Enabled {
+		go fn(utilwait.NeverStop)
+		return
+	}
+
+	id, err := os.Hostname()
+	if err != nil {
+		glog.Fatalf("Unable to determine hostname for %s leader election identity: %v", name, err)
+	}
+
+	rl, err := resourcelock.New(
+		le.ResourceLock,
+		"kube-system",
+		name,
+		internalclientset.FromUnversionedClient(c.KubeClient).Core(),
+		resourcelock.ResourceLockConfig{Identity: id},
+	)
+	if err != nil {
+		glog.Fatalf("Unable to create leader election lock for %s: %v", name, err)
+	}
+
+	go leaderelection.RunOrDie(leaderelection.LeaderElectionConfig{
+		Lock:          rl,
+		LeaseDuration: le.LeaseDuration.Duration,
+		RenewDeadline: le.RenewDeadline.Duration,
+		RetryPeriod:   le.RetryPeriod.Duration,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: fn,
+			OnStoppedLeading: func() {
+				glog.Fatalf("Leaderelection lost for %s, exiting so another master can take over", name)
+			},
+		},
+	})
+}
+
 // RunNamespaceController starts the Kubernetes Namespace Manager
 func (c *MasterConfig) RunNamespaceController() {
 	versions := []string{}
@@ -92,44 +144,64 @@ func (c *MasterConfig) RunNamespaceController() {
 	}
 	apiVersions := &unversioned.APIVersions{Versions: versions}
 	namespaceController := namespacecontroller.NewNamespaceController(internalclientset.FromUnversionedClient(c.KubeClient), apiVersions, c.ControllerManager.NamespaceSyncPeriod)
-	go namespaceController.Run(c.ControllerManager.ConcurrentNamespaceSyncs, utilwait.NeverStop)
+	c.runLeaderElected("namespace-controller", func(stopCh <-chan struct{}) {
+		namespaceController.Run(c.ControllerManager.ConcurrentNamespaceSyncs, stopCh)
+	})
 }
 
-func (c *MasterConfig) RunThirdPartyAnalyticsController(kubeClient *client.Client, osClient osclient.Interface) {
-	ctrl := oscontroller.NewThirdPartyAnalyticsController(internalclientset.FromUnversionedClient(kubeClient), osClient)
-	ctrl.Run(utilwait.NeverStop, 1)
-}
+// RunGarbageCollectorController starts the Kubernetes garbage collector, which deletes
+// objects that have no remaining owners according to their OwnerReferences. It is gated
+// behind EnableGarbageCollector since cascading delete changes long-standing behavior for
+// clusters that rely on orphaned objects sticking around.
+func (c *MasterConfig) RunGarbageCollectorController(osClient osclient.Interface, restConfig restclient.Config) {
+	if !c.ControllerManager.EnableGarbageCollector {
+		return
+	}
 
+	preferredResources, err := c.KubeClient.Discovery().ServerPreferredResources()
+	if err != nil {
+		glog.Fatalf("Unable to get preferred resources for garbage collection: %v", err)
+	}
+	// Origin resources (BuildConfig/Build, DeploymentConfig, ImageStream, ...) live under their
+	// own API group and aren't served by c.KubeClient's discovery; without them, cascading
+	// delete via OwnerReferences wouldn't work cross-group for any object an Origin resource owns.
+	originPreferredResources, err := osClient.Discovery().ServerPreferredResources()
+	if err != nil {
+		glog.Fatalf("Unable to get preferred Origin resources for garbage collection: %v", err)
+	}
+	preferredResources = append(preferredResources, originPreferredResources...)
 
-// RunPersistentVolumeClaimBinder starts the Kubernetes Persistent Volume Claim Binder
-func (c *MasterConfig) RunPersistentVolumeClaimBinder(client *client.Client) {
-	binder := volumeclaimbinder.NewPersistentVolumeClaimBinder(internalclientset.FromUnversionedClient(client), c.ControllerManager.PVClaimBinderSyncPeriod)
-	binder.Run()
-}
+	deletableResources := discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"delete", "list", "watch"}}, preferredResources)
+	deletableGroupVersionResources, err := discovery.GroupVersionResources(deletableResources)
+	if err != nil {
+		glog.Fatalf("Unable to determine deletable GroupVersionResources for garbage collection: %v", err)
+	}
+
+	// a metadata-only codec keeps the OwnerReferences scan cheap; the full client pool is
+	// used only when the garbage collector actually needs to issue the delete.
+	metaOnlyClientPool := dynamic.NewClientPoolForRestConfig(&restConfig, dynamic.LegacyAPIPathResolverFunc, dynamic.MetadataOnlyJSONCodecFactory{})
+	clientPool := dynamic.NewClientPool(&restConfig, c.RESTMapper, dynamic.LegacyAPIPathResolverFunc)
 
-func (c *MasterConfig) RunPersistentVolumeProvisioner(client *client.Client) {
-	provisioner, err := kctrlmgr.NewVolumeProvisioner(c.CloudProvider, c.ControllerManager.VolumeConfigFlags)
+	gc, err := garbagecollector.NewGarbageCollector(metaOnlyClientPool, clientPool, c.RESTMapper, deletableGroupVersionResources)
 	if err != nil {
-		// a provisioner was expected but encountered an error
-		glog.Fatal(err)
+		glog.Fatalf("Unable to start garbage collector: %v", err)
 	}
+	go gc.Run(c.ControllerManager.ConcurrentGCSyncs, utilwait.NeverStop)
+}
 
-	// not all cloud providers have a provisioner.
-	if provisioner != nil {
-		allPlugins := []volume.VolumePlugin{}
-		allPlugins = append(allPlugins, aws_ebs.ProbeVolumePlugins()...)
-		allPlugins = append(allPlugins, gce_pd.ProbeVolumePlugins()...)
-		allPlugins = append(allPlugins, cinder.ProbeVolumePlugins()...)
-		controllerClient := volumeclaimbinder.NewControllerClient(internalclientset.FromUnversionedClient(client))
-		provisionerController, err := volumeclaimbinder.NewPersistentVolumeProvisionerController(controllerClient, c.ControllerManager.PVClaimBinderSyncPeriod, allPlugins, provisioner, c.CloudProvider)
-		if err != nil {
-			glog.Fatalf("Could not start Persistent Volume Provisioner: %+v", err)
-		}
-		provisionerController.Run()
+func (c *MasterConfig) RunThirdPartyAnalyticsController(analyticsConfig oscontroller.AnalyticsConfig) {
+	ctrl, err := oscontroller.NewThirdPartyAnalyticsController(c.InformerFactory, c.OSInformerFactory, c.AnalyticsPolicyInformer, analyticsConfig)
+	if err != nil {
+		glog.Fatalf("Unable to start third-party analytics controller: %v", err)
 	}
+	go ctrl.Run(utilwait.NeverStop, 1)
 }
 
-func (c *MasterConfig) RunPersistentVolumeClaimRecycler(recyclerImageName string, client *client.Client, namespace string) {
+// RunPersistentVolumeController starts the unified Kubernetes Persistent Volume controller. It
+// replaces the old binder/provisioner/recycler trio with a single sync loop so the three no
+// longer race on the same PV/PVC objects, and it is the only place that can honor
+// EnableDynamicProvisioning / StorageClass-driven provisioning.
+func (c *MasterConfig) RunPersistentVolumeController(client *client.Client, recyclerImageName, namespace string) {
 	uid := int64(0)
 	defaultScrubPod := volume.NewPersistentVolumeRecyclerPodTemplate()
 	defaultScrubPod.Namespace = namespace
@@ -172,13 +244,60 @@ func (c *MasterConfig) RunPersistentVolumeClaimRecycler(recyclerImageName string
 	allPlugins = append(allPlugins, gce_pd.ProbeVolumePlugins()...)
 	allPlugins = append(allPlugins, cinder.ProbeVolumePlugins()...)
 
-	recycler, err := volumeclaimbinder.NewPersistentVolumeRecycler(internalclientset.FromUnversionedClient(client), c.ControllerManager.PVClaimBinderSyncPeriod, volumeConfig.PersistentVolumeRecyclerMaximumRetry, allPlugins, c.CloudProvider)
+	provisioner, err := kctrlmgr.NewVolumeProvisioner(c.CloudProvider, volumeConfig)
+	if err != nil {
+		// a provisioner was expected but encountered an error
+		glog.Fatal(err)
+	}
+
+	pvController, err := volumeclaimbinder.NewPersistentVolumeController(
+		internalclientset.FromUnversionedClient(client),
+		c.ControllerManager.PVClaimBinderSyncPeriod,
+		provisioner,
+		allPlugins,
+		c.CloudProvider,
+		c.ControllerManager.ClusterName,
+		c.InformerFactory.PersistentVolumes().Informer(),
+		c.InformerFactory.PersistentVolumeClaims().Informer(),
+		volumeConfig.EnableDynamicProvisioning,
+	)
 	if err != nil {
-		glog.Fatalf("Could not start Persistent Volume Recycler: %+v", err)
+		glog.Fatalf("Could not start Persistent Volume Controller: %+v", err)
 	}
-	recycler.Run()
+	c.runLeaderElected("persistent-volume-controller", pvController.Run)
+}
+
+// RunPersistentVolumeClaimBinder starts the Kubernetes Persistent Volume Claim Binder.
+//
+// Deprecated: superseded by RunPersistentVolumeController, which folds the binder,
+// provisioner, and recycler into a single sync loop. Kept so older master-config.yaml
+// files that still invoke the binder alone keep working; it now delegates to the unified
+// controller instead of running an independent binder loop, so it can no longer race with a
+// provisioner or recycler started alongside it.
+func (c *MasterConfig) RunPersistentVolumeClaimBinder(client *client.Client, recyclerImageName, namespace string) {
+	deprecatedPVControllerOnce.Do(func() {
+		c.RunPersistentVolumeController(client, recyclerImageName, namespace)
+	})
+}
+
+// Deprecated: superseded by RunPersistentVolumeController; see RunPersistentVolumeClaimBinder.
+func (c *MasterConfig) RunPersistentVolumeProvisioner(client *client.Client, recyclerImageName, namespace string) {
+	deprecatedPVControllerOnce.Do(func() {
+		c.RunPersistentVolumeController(client, recyclerImageName, namespace)
+	})
+}
+
+// Deprecated: superseded by RunPersistentVolumeController; see RunPersistentVolumeClaimBinder.
+func (c *MasterConfig) RunPersistentVolumeClaimRecycler(recyclerImageName string, client *client.Client, namespace string) {
+	deprecatedPVControllerOnce.Do(func() {
+		c.RunPersistentVolumeController(client, recyclerImageName, namespace)
+	})
 }
 
+// deprecatedPVControllerOnce makes the three deprecated wrappers above start the unified
+// controller at most once between them, however many of them a config invokes.
+var deprecatedPVControllerOnce sync.Once
+
 // attemptToLoadRecycler tries decoding a pod from a filepath for use as a recycler for a volume.
 // If a path is not set as a CLI flag, no load will be attempted and no error returned.
 // If a path is set and the pod was successfully loaded, the recycler pod will be set on the config and no error returned.
@@ -200,14 +319,38 @@ func attemptToLoadRecycler(path string, config *volume.VolumeConfig) error {
 
 // RunReplicationController starts the Kubernetes replication controller sync loop
 func (c *MasterConfig) RunReplicationController(client *client.Client) {
-	controllerManager := replicationcontroller.NewReplicationManager(internalclientset.FromUnversionedClient(client), kctrlmgr.ResyncPeriod(c.ControllerManager), replicationcontroller.BurstReplicas)
-	go controllerManager.Run(c.ControllerManager.ConcurrentRCSyncs, utilwait.NeverStop)
+	controllerManager := replicationcontroller.NewReplicationManager(c.InformerFactory.Pods().Informer(), internalclientset.FromUnversionedClient(client), kctrlmgr.ResyncPeriod(c.ControllerManager), replicationcontroller.BurstReplicas)
+	c.runLeaderElected("replication-controller", func(stopCh <-chan struct{}) {
+		controllerManager.Run(c.ControllerManager.ConcurrentRCSyncs, stopCh)
+	})
 }
 
 // RunJobController starts the Kubernetes job controller sync loop
 func (c *MasterConfig) RunJobController(client *client.Client) {
-	controller := jobcontroller.NewJobController(internalclientset.FromUnversionedClient(client), kctrlmgr.ResyncPeriod(c.ControllerManager))
-	go controller.Run(c.ControllerManager.ConcurrentJobSyncs, utilwait.NeverStop)
+	controller := jobcontroller.NewJobController(c.InformerFactory.Pods().Informer(), internalclientset.FromUnversionedClient(client), kctrlmgr.ResyncPeriod(c.ControllerManager))
+	c.runLeaderElected("job-controller", func(stopCh <-chan struct{}) {
+		controller.Run(c.ControllerManager.ConcurrentJobSyncs, stopCh)
+	})
+}
+
+// RunAttachDetachController starts the Kubernetes attach/detach controller, which reconciles
+// the attached state of volumes with pods that reference them, using the shared pod/node/PVC/PV
+// informers so it doesn't open yet another set of reflectors onto the same objects.
+func (c *MasterConfig) RunAttachDetachController(client *client.Client) {
+	attachDetachController, err := attachdetachcontroller.NewAttachDetachController(
+		internalclientset.FromUnversionedClient(client),
+		c.InformerFactory.Pods().Informer(),
+		c.InformerFactory.Nodes().Informer(),
+		c.InformerFactory.PersistentVolumeClaims().Informer(),
+		c.InformerFactory.PersistentVolumes().Informer(),
+		c.CloudProvider,
+		kctrlmgr.ProbeAttachableVolumePlugins(c.ControllerManager.VolumeConfigFlags),
+		kctrlmgr.ResyncPeriod(c.ControllerManager)(),
+	)
+	if err != nil {
+		glog.Fatalf("Unable to start attach/detach controller: %v", err)
+	}
+	go attachDetachController.Run(utilwait.NeverStop)
 }
 
 // RunHPAController starts the Kubernetes hpa controller sync loop
@@ -215,19 +358,24 @@ func (c *MasterConfig) RunHPAController(oc *osclient.Client, kc *client.Client,
 	clientsetClient := internalclientset.FromUnversionedClient(kc)
 	delegScaleNamespacer := osclient.NewDelegatingScaleNamespacer(oc, kc)
 	podautoscaler := podautoscalercontroller.NewHorizontalController(clientsetClient, delegScaleNamespacer, clientsetClient, metrics.NewHeapsterMetricsClient(clientsetClient, heapsterNamespace, "https", "heapster", ""))
-	podautoscaler.Run(c.ControllerManager.HorizontalPodAutoscalerSyncPeriod)
+	c.runLeaderElected("hpa-controller", func(stopCh <-chan struct{}) {
+		podautoscaler.Run(c.ControllerManager.HorizontalPodAutoscalerSyncPeriod)
+	})
 }
 
 func (c *MasterConfig) RunDaemonSetsController(client *client.Client) {
-	controller := daemon.NewDaemonSetsController(internalclientset.FromUnversionedClient(client), kctrlmgr.ResyncPeriod(c.ControllerManager))
-	go controller.Run(c.ControllerManager.ConcurrentDSCSyncs, utilwait.NeverStop)
+	controller := daemon.NewDaemonSetsController(c.InformerFactory.Pods().Informer(), internalclientset.FromUnversionedClient(client), kctrlmgr.ResyncPeriod(c.ControllerManager))
+	c.runLeaderElected("daemonset-controller", func(stopCh <-chan struct{}) {
+		controller.Run(c.ControllerManager.ConcurrentDSCSyncs, stopCh)
+	})
 }
 
 // RunEndpointController starts the Kubernetes replication controller sync loop
 func (c *MasterConfig) RunEndpointController() {
-	endpoints := endpointcontroller.NewEndpointController(internalclientset.FromUnversionedClient(c.KubeClient), kctrlmgr.ResyncPeriod(c.ControllerManager))
-	go endpoints.Run(c.ControllerManager.ConcurrentEndpointSyncs, utilwait.NeverStop)
-
+	endpoints := endpointcontroller.NewEndpointController(c.InformerFactory.Pods().Informer(), internalclientset.FromUnversionedClient(c.KubeClient))
+	c.runLeaderElected("endpoint-controller", func(stopCh <-chan struct{}) {
+		endpoints.Run(c.ControllerManager.ConcurrentEndpointSyncs, stopCh)
+	})
 }
 
 // RunScheduler starts the Kubernetes scheduler
@@ -241,34 +389,41 @@ func (c *MasterConfig) RunScheduler() {
 	eventcast.StartRecordingToSink(c.KubeClient.Events(""))
 
 	s := scheduler.New(config)
-	s.Run()
+	c.runLeaderElected("scheduler", func(stopCh <-chan struct{}) {
+		s.Run()
+	})
 }
 
 // RunResourceQuotaManager starts the resource quota manager
 func (c *MasterConfig) RunResourceQuotaManager() {
 	client := internalclientset.FromUnversionedClient(c.KubeClient)
-	resourceQuotaRegistry := quotainstall.NewRegistry(client)
+	resourceQuotaRegistry := quotainstall.NewRegistry(client, c.InformerFactory)
 	groupKindsToReplenish := []unversioned.GroupKind{
 		kapi.Kind("Pod"),
 		kapi.Kind("Service"),
 		kapi.Kind("ReplicationController"),
 		kapi.Kind("PersistentVolumeClaim"),
 		kapi.Kind("Secret"),
+		kapi.Kind("ConfigMap"),
 	}
 	resourceQuotaControllerOptions := &kresourcequota.ResourceQuotaControllerOptions{
-		KubeClient:            client,
-		ResyncPeriod:          controller.StaticResyncPeriodFunc(c.ControllerManager.ResourceQuotaSyncPeriod),
-		Registry:              resourceQuotaRegistry,
-		GroupKindsToReplenish: groupKindsToReplenish,
-		ControllerFactory:     kresourcequota.NewReplenishmentControllerFactory(client),
+		KubeClient:                client,
+		ResyncPeriod:              controller.StaticResyncPeriodFunc(c.ControllerManager.ResourceQuotaSyncPeriod),
+		ReplenishmentResyncPeriod: controller.StaticResyncPeriodFunc(c.ControllerManager.ReplenishmentResyncPeriod),
+		Registry:                  resourceQuotaRegistry,
+		GroupKindsToReplenish:     groupKindsToReplenish,
+		ControllerFactory:         kresourcequota.NewReplenishmentControllerFactory(c.InformerFactory, client),
 	}
-	go kresourcequota.NewResourceQuotaController(resourceQuotaControllerOptions).Run(c.ControllerManager.ConcurrentResourceQuotaSyncs, utilwait.NeverStop)
+	resourceQuotaController := kresourcequota.NewResourceQuotaController(resourceQuotaControllerOptions)
+	c.runLeaderElected("resourcequota-controller", func(stopCh <-chan struct{}) {
+		resourceQuotaController.Run(c.ControllerManager.ConcurrentResourceQuotaSyncs, stopCh)
+	})
 }
 
 // RunNodeController starts the node controller
 func (c *MasterConfig) RunNodeController() {
 	s := c.ControllerManager
-	controller := nodecontroller.NewNodeController(
+	nodeController := nodecontroller.NewNodeController(
 		c.CloudProvider,
 		internalclientset.FromUnversionedClient(c.KubeClient),
 		s.PodEvictionTimeout,
@@ -284,7 +439,21 @@ func (c *MasterConfig) RunNodeController() {
 		s.AllocateNodeCIDRs,
 	)
 
-	controller.Run(s.NodeSyncPeriod)
+	c.runLeaderElected("node-controller", func(stopCh <-chan struct{}) {
+		nodeController.Run(s.NodeSyncPeriod)
+	})
+}
+
+// RunPodGCController starts the terminated pod garbage collector, which bounds the number of
+// terminated pods kept around in a namespace by deleting the oldest ones (by completion time)
+// once the count exceeds TerminatedPodGCThreshold. Without this, completed Build/Job pods
+// accumulate indefinitely on long-running clusters.
+func (c *MasterConfig) RunPodGCController(client *client.Client) {
+	if c.ControllerManager.TerminatedPodGCThreshold <= 0 {
+		return
+	}
+	controller := gccontroller.New(internalclientset.FromUnversionedClient(client), kctrlmgr.ResyncPeriod(c.ControllerManager), c.ControllerManager.TerminatedPodGCThreshold)
+	go controller.Run(utilwait.NeverStop)
 }
 
 func (c *MasterConfig) createSchedulerConfig() (*scheduler.Config, error) {
@@ -293,19 +462,49 @@ func (c *MasterConfig) createSchedulerConfig() (*scheduler.Config, error) {
 
 	// TODO make the rate limiter configurable
 	configFactory := factory.NewConfigFactory(c.KubeClient, kapi.DefaultSchedulerName)
-	if _, err := os.Stat(c.Options.SchedulerConfigFile); err == nil {
+
+	if len(c.Options.SchedulerPolicyConfigMap) > 0 {
+		ns, name, err := splitConfigMapRef(c.Options.SchedulerPolicyConfigMap)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedulerPolicyConfigMap %q: %v", c.Options.SchedulerPolicyConfigMap, err)
+		}
+		configMap, err := c.KubeClient.ConfigMaps(ns).Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read scheduler policy ConfigMap %s/%s: %v", ns, name, err)
+		}
+		configData = []byte(configMap.Data[kapi.SchedulerPolicyConfigMapKey])
+		if err := runtime.DecodeInto(latestschedulerapi.Codec, configData, &policy); err != nil {
+			return nil, fmt.Errorf("invalid scheduler configuration in ConfigMap %s/%s: %v", ns, name, err)
+		}
+	} else if _, err := os.Stat(c.Options.SchedulerConfigFile); err == nil {
 		configData, err = ioutil.ReadFile(c.Options.SchedulerConfigFile)
 		if err != nil {
 			return nil, fmt.Errorf("unable to read scheduler config: %v", err)
 		}
-		err = runtime.DecodeInto(latestschedulerapi.Codec, configData, &policy)
-		if err != nil {
+		if err := runtime.DecodeInto(latestschedulerapi.Codec, configData, &policy); err != nil {
 			return nil, fmt.Errorf("invalid scheduler configuration: %v", err)
 		}
+	} else {
+		// if neither a config file nor a ConfigMap is provided, use the default provider
+		return configFactory.CreateFromProvider(factory.DefaultProvider)
+	}
 
-		return configFactory.CreateFromConfig(policy)
+	for _, extenderConfig := range policy.ExtenderConfigs {
+		extender, err := scheduler.NewHTTPExtender(&extenderConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create scheduler extender from policy: %v", err)
+		}
+		configFactory.AddExtender(extender)
 	}
 
-	// if the config file isn't provided, use the default provider
-	return configFactory.CreateFromProvider(factory.DefaultProvider)
+	return configFactory.CreateFromConfig(policy)
+}
+
+// splitConfigMapRef splits a "namespace/name" reference to a ConfigMap.
+func splitConfigMapRef(ref string) (namespace, name string, err error) {
+	parts := strings.SplitN(ref, "/", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("expected format <namespace>/<name>")
+	}
+	return parts[0], parts[1], nil
 }
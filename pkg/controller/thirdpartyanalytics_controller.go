@@ -17,277 +17,1016 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
-	"k8s.io/kubernetes/pkg/api"
-	buildapi "github.com/openshift/origin/pkg/build/api"
-	deployapi "github.com/openshift/origin/pkg/deploy/api"
-	routeapi "github.com/openshift/origin/pkg/route/api"
-	templateapi "github.com/openshift/origin/pkg/template/api"
+	analyticsapi "github.com/openshift/origin/pkg/analytics/api"
+	"github.com/openshift/origin/pkg/analytics/cloudevents"
+	"github.com/openshift/origin/pkg/controller/shared"
+	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/client/cache"
-	osclient "github.com/openshift/origin/pkg/client"
-	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
 	"k8s.io/kubernetes/pkg/controller/framework"
-	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/controller/informers"
+	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/util/wait"
 	"k8s.io/kubernetes/pkg/util/workqueue"
-	"k8s.io/kubernetes/pkg/watch"
 
 	"github.com/golang/glog"
 )
 
+// maxTrackRetries bounds how many times a failed tracking event is retried before it is
+// handed to the dead-letter sink instead of spinning forever against an outage.
+const maxTrackRetries = 15
+
 // ThirdPartyAnalyticsController is a controller that synchronizes PersistentVolumeClaims.
 type ThirdPartyAnalyticsController struct {
-	controllers   map[string]*framework.Controller
-	queue         *workqueue.Type
+	queue            workqueue.RateLimitingInterface
+	trackers         map[string]AnalyticsTracker
+	deadLetter       DeadLetterSink
+	cacheSyncs       []cache.InformerSynced
+	namespaceStore   cache.Store
+	policies         *policyStore
+	maxBatchSize     int
+	maxBatchInterval time.Duration
 }
 
-// NewThirdPartyAnalyticsController creates a new ThirdPartyAnalyticsController
-func NewThirdPartyAnalyticsController(kubeClient clientset.Interface, osClient osclient.Interface) *ThirdPartyAnalyticsController {
+// defaultMaxBatchSize and defaultMaxBatchInterval are used when AnalyticsConfig leaves
+// MaxBatchSize/MaxBatchInterval unset.
+const (
+	defaultMaxBatchSize     = 100
+	defaultMaxBatchInterval = 5 * time.Second
+)
+
+// NewThirdPartyAnalyticsController creates a new ThirdPartyAnalyticsController. Rather than
+// building its own reflectors per watched resource, it registers event handlers on the shared
+// Kubernetes and OpenShift informers so it reuses the caches other controllers already
+// maintain instead of doubling the API server's watch load. config selects which analytics
+// backends are available to report to; policyInformer watches AnalyticsPolicy objects, which
+// decide at runtime which of those backends, if any, a given event is actually sent to.
+func NewThirdPartyAnalyticsController(kubeInformers informers.SharedInformerFactory, osInformers shared.InformerFactory, policyInformer cache.SharedIndexInformer, config AnalyticsConfig) (*ThirdPartyAnalyticsController, error) {
+	trackers, err := newAnalyticsTrackers(config)
+	if err != nil {
+		return nil, err
+	}
+	deadLetter, err := newDeadLetterSink(config.DeadLetter)
+	if err != nil {
+		return nil, err
+	}
+
+	maxBatchSize := config.MaxBatchSize
+	if maxBatchSize <= 0 {
+		maxBatchSize = defaultMaxBatchSize
+	}
+	maxBatchInterval := config.MaxBatchInterval.Duration
+	if maxBatchInterval <= 0 {
+		maxBatchInterval = defaultMaxBatchInterval
+	}
+
 	ctrl := &ThirdPartyAnalyticsController{
-		controllers: make(map[string]*framework.Controller),
-		queue:       workqueue.New(),
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 5*time.Minute), "thirdpartyanalytics"),
+		trackers:         trackers,
+		deadLetter:       deadLetter,
+		namespaceStore:   kubeInformers.Namespaces().Informer().GetStore(),
+		policies:         newPolicyStore(),
+		maxBatchSize:     maxBatchSize,
+		maxBatchInterval: maxBatchInterval,
 	}
 
-	watches := map[string]struct {
-		objType   runtime.Object
-		listFunc  func(options api.ListOptions) (runtime.Object, error)
-		watchFunc func(options api.ListOptions) (watch.Interface, error)
-	}{
+	policyInformer.AddEventHandler(framework.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { ctrl.policies.onPolicyChanged() },
+		UpdateFunc: func(oldObj, newObj interface{}) { ctrl.policies.onPolicyChanged() },
+		DeleteFunc: func(obj interface{}) { ctrl.policies.onPolicyChanged() },
+	})
+	ctrl.policies.lister = policyInformer.GetStore()
+	ctrl.cacheSyncs = append(ctrl.cacheSyncs, policyInformer.HasSynced)
+
+	watchedInformers := map[string]cache.SharedIndexInformer{
 		// Kubernetes objects
-		"pod": {
-			objType: &api.Pod{},
-			listFunc: func(options api.ListOptions) (runtime.Object, error) {
-				return kubeClient.Core().Pods(api.NamespaceAll).List(options)
-			},
-			watchFunc: func(options api.ListOptions) (watch.Interface, error) {
-				return kubeClient.Core().Pods(api.NamespaceAll).Watch(options)
-			},
-		},
-		"replication_controller": {
-			objType: &api.ReplicationController{},
-			listFunc: func(options api.ListOptions) (runtime.Object, error) {
-				return kubeClient.Core().ReplicationControllers(api.NamespaceAll).List(options)
-			},
-			watchFunc: func(options api.ListOptions) (watch.Interface, error) {
-				return kubeClient.Core().ReplicationControllers(api.NamespaceAll).Watch(options)
-			},
-		},
-		"pvclaim": {
-			objType: &api.PersistentVolumeClaim{},
-			listFunc: func(options api.ListOptions) (runtime.Object, error) {
-				return kubeClient.Core().PersistentVolumeClaims(api.NamespaceAll).List(options)
-			},
-			watchFunc: func(options api.ListOptions) (watch.Interface, error) {
-				return kubeClient.Core().PersistentVolumeClaims(api.NamespaceAll).Watch(options)
-			},
-		},
-		"secret": {
-			objType: &api.Secret{},
-			listFunc: func(options api.ListOptions) (runtime.Object, error) {
-				return kubeClient.Core().Secrets(api.NamespaceAll).List(options)
-			},
-			watchFunc: func(options api.ListOptions) (watch.Interface, error) {
-				return kubeClient.Core().Secrets(api.NamespaceAll).Watch(options)
-			},
-		},
-		"service": {
-			objType: &api.Service{},
-			listFunc: func(options api.ListOptions) (runtime.Object, error) {
-				return kubeClient.Core().Services(api.NamespaceAll).List(options)
-			},
-			watchFunc: func(options api.ListOptions) (watch.Interface, error) {
-				return kubeClient.Core().Services(api.NamespaceAll).Watch(options)
-			},
-		},
-		"namespace": {
-			objType: &api.Service{},
-			listFunc: func(options api.ListOptions) (runtime.Object, error) {
-				return kubeClient.Core().Namespaces().List(options)
-			},
-			watchFunc: func(options api.ListOptions) (watch.Interface, error) {
-				return kubeClient.Core().Namespaces().Watch(options)
-			},
-		},
+		"pod":                    kubeInformers.Pods().Informer(),
+		"replication_controller": kubeInformers.ReplicationControllers().Informer(),
+		"pvclaim":                kubeInformers.PersistentVolumeClaims().Informer(),
+		"secret":                 kubeInformers.Secrets().Informer(),
+		"service":                kubeInformers.Services().Informer(),
+		"namespace":              kubeInformers.Namespaces().Informer(),
 
 		// Openshift objects
-		"deployment": {
-			objType: &deployapi.DeploymentConfig{},
-			listFunc: func(options api.ListOptions) (runtime.Object, error) {
-				return osClient.DeploymentConfigs(api.NamespaceAll).List(options)
-			},
-			watchFunc: func(options api.ListOptions) (watch.Interface, error) {
-				return osClient.DeploymentConfigs(api.NamespaceAll).Watch(options)
-			},
-		},
-		"route": {
-			objType: &routeapi.Route{},
-			listFunc: func(options api.ListOptions) (runtime.Object, error) {
-				return osClient.Routes(api.NamespaceAll).List(options)
-			},
-			watchFunc: func(options api.ListOptions) (watch.Interface, error) {
-				return osClient.Routes(api.NamespaceAll).Watch(options)
-			},
-		},
-		"build": {
-			objType: &buildapi.Build{},
-			listFunc: func(options api.ListOptions) (runtime.Object, error) {
-				return osClient.Builds(api.NamespaceAll).List(options)
-			},
-			watchFunc: func(options api.ListOptions) (watch.Interface, error) {
-				return osClient.Builds(api.NamespaceAll).Watch(options)
+		"deployment": osInformers.DeploymentConfigs().Informer(),
+		"route":      osInformers.Routes().Informer(),
+		"build":      osInformers.Builds().Informer(),
+		"template":   osInformers.Templates().Informer(),
+	}
+
+	for name, informer := range watchedInformers {
+		name := name
+		informer.AddEventHandler(framework.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				accessor, err := meta.Accessor(obj)
+				if err != nil {
+					glog.Errorf("object has no meta: %v", err)
+					return
+				}
+				ctrl.enqueue(name, "add", accessor)
 			},
-		},
-		"template": {
-			objType: &templateapi.Template{},
-			listFunc: func(options api.ListOptions) (runtime.Object, error) {
-				return osClient.Templates(api.NamespaceAll).List(options)
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				accessor, err := meta.Accessor(newObj)
+				if err != nil {
+					glog.Errorf("object has no meta: %v", err)
+					return
+				}
+				ctrl.enqueue(name, "update", accessor)
 			},
-			watchFunc: func(options api.ListOptions) (watch.Interface, error) {
-				return osClient.Templates(api.NamespaceAll).Watch(options)
+			DeleteFunc: func(obj interface{}) {
+				if unk, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = unk.Obj
+				}
+				accessor, err := meta.Accessor(obj)
+				if err != nil {
+					glog.Errorf("object has no meta: %v", err)
+					return
+				}
+				ctrl.enqueue(name, "delete", accessor)
 			},
-		},
+		})
+		ctrl.cacheSyncs = append(ctrl.cacheSyncs, informer.HasSynced)
 	}
+	return ctrl, nil
+}
 
-	for name, watch := range watches {
-		_, c := framework.NewInformer(
-			&cache.ListWatch{
-				ListFunc:  watch.listFunc,
-				WatchFunc: watch.watchFunc,
-			},
-			watch.objType,
-			0, // 0 is no re-sync
-			framework.ResourceEventHandlerFuncs{
-				AddFunc: func(obj interface{}) {
-					meta, err := meta.Accessor(obj)
-					if err != nil {
-						glog.Errorf("object has no meta: %v", err)
-					}
-					ctrl.enqueue(name, "add", meta.GetNamespace())
-				},
-				UpdateFunc: func(oldObj, newObj interface{}) {
-					meta, err := meta.Accessor(newObj)
-					if err != nil {
-						glog.Errorf("object has no meta: %v", err)
-					}
-					ctrl.enqueue(name, "update", meta.GetNamespace())
-				},
-				DeleteFunc: func(obj interface{}) {
-					unk, ok := obj.(cache.DeletedFinalStateUnknown)
-					if ok {
-						obj = unk.Obj
-					}
-					meta, err := meta.Accessor(obj)
-					if err != nil {
-						glog.Errorf("object has no meta: %v", err)
-					}
-					ctrl.enqueue(name, "delete", meta.GetNamespace())
-				},
-			},
-		)
-		ctrl.controllers[name] = c
+// enqueue decides, by consulting the current AnalyticsPolicy set, which backends (if any) want
+// this event and only then adds it to the queue. This is how policy changes take effect without
+// re-registering informer handlers: SharedIndexInformer in this vintage has no
+// RemoveEventHandler, so "dynamically add/remove event handlers" is implemented by gating here
+// instead, against the rule snapshot policyStore rebuilds whenever an AnalyticsPolicy changes. obj carries the object's
+// full metadata so a CloudEvents-format backend can report more than a bare namespace string.
+func (c *ThirdPartyAnalyticsController) enqueue(objName, action string, obj meta.Object) {
+	namespace := obj.GetNamespace()
+	backends := c.policies.backendsFor(objName, action, labels.Set(obj.GetLabels()), c.namespaceLabels(namespace))
+	if len(backends) == 0 {
+		return
+	}
+	glog.V(3).Infof("Enqueueing for tracking %s %s %s/%s -> %v", objName, action, namespace, obj.GetName(), backends)
+	c.queue.Add(newEvent(objName, action, namespace, obj.GetName(), string(obj.GetUID()), obj.GetResourceVersion(), backends))
+}
+
+// namespaceLabels looks up the labels of namespace from the shared namespace informer's store,
+// so AnalyticsPolicyRule.NamespaceSelector can be evaluated without a separate API call.
+func (c *ThirdPartyAnalyticsController) namespaceLabels(namespace string) labels.Set {
+	obj, exists, err := c.namespaceStore.GetByKey(namespace)
+	if err != nil || !exists {
+		return nil
+	}
+	ns, ok := obj.(*kapi.Namespace)
+	if !ok {
+		return nil
+	}
+	return labels.Set(ns.Labels)
+}
+
+// policyStore evaluates the currently cached AnalyticsPolicy objects against an incoming
+// event. lister is backed by the policy informer's store; rules is a flattened snapshot of
+// every AnalyticsPolicy's Spec.Rules, rebuilt only when onPolicyChanged fires rather than on
+// every watched-object event, so policy evaluation doesn't re-list the store on the hot path.
+type policyStore struct {
+	lister cache.Store
+
+	mu    sync.RWMutex
+	rules []analyticsapi.AnalyticsPolicyRule
+}
+
+func newPolicyStore() *policyStore {
+	return &policyStore{}
+}
+
+// onPolicyChanged rebuilds the cached rule snapshot from the policy informer's store. It is
+// wired up as the Add/Update/Delete handler for AnalyticsPolicy objects.
+func (s *policyStore) onPolicyChanged() {
+	var rules []analyticsapi.AnalyticsPolicyRule
+	if s.lister != nil {
+		for _, obj := range s.lister.List() {
+			policy, ok := obj.(*analyticsapi.AnalyticsPolicy)
+			if !ok {
+				continue
+			}
+			rules = append(rules, policy.Spec.Rules...)
+		}
+	}
+
+	s.mu.Lock()
+	s.rules = rules
+	s.mu.Unlock()
+	glog.V(4).Infof("AnalyticsPolicy set changed, %d rule(s) now active", len(rules))
+}
+
+// backendsFor returns the deduplicated names of the backends that should receive an event for
+// resource, given the verb and the labels of the object and its namespace. An event matching no
+// rule is not tracked by any backend.
+func (s *policyStore) backendsFor(resource, verb string, objLabels, nsLabels labels.Set) []string {
+	s.mu.RLock()
+	rules := s.rules
+	s.mu.RUnlock()
+
+	seen := map[string]bool{}
+	var refs []string
+	for _, rule := range rules {
+		if rule.Resource != resource {
+			continue
+		}
+		if len(rule.Verbs) > 0 && !containsString(rule.Verbs, verb) {
+			continue
+		}
+		if rule.LabelSelector != nil {
+			sel, err := unversioned.LabelSelectorAsSelector(rule.LabelSelector)
+			if err != nil || !sel.Matches(objLabels) {
+				continue
+			}
+		}
+		if rule.NamespaceSelector != nil {
+			sel, err := unversioned.LabelSelectorAsSelector(rule.NamespaceSelector)
+			if err != nil || !sel.Matches(nsLabels) {
+				continue
+			}
+		}
+		if seen[rule.BackendRef] {
+			continue
+		}
+		seen[rule.BackendRef] = true
+		refs = append(refs, rule.BackendRef)
 	}
-	return ctrl
+	return refs
 }
 
-func (c *ThirdPartyAnalyticsController) enqueue(objName, action, namespace string) {
-	glog.V(3).Infof("Enqueueing for tracking %s %s %s", objName, action, namespace)
-	c.queue.Add(newEvent(objName, action, namespace))
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
 }
 
-// Run starts all the watches within this controller and starts workers to process events
+// Run waits for the shared informers this controller registered against to sync, then starts
+// workers to process events off the queue. It no longer owns any watches of its own.
 func (c *ThirdPartyAnalyticsController) Run(stopCh <-chan struct{}, workers int) {
+	defer c.queue.ShutDown()
+
 	glog.V(5).Infof("Starting ThirdPartyAnalyticsController\n")
-	for name, c := range c.controllers {
-		glog.V(5).Infof("Starting watch for %s", name)
-		go c.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, c.cacheSyncs...) {
+		return
 	}
+
 	for i := 0; i < workers; i++ {
 		go wait.Until(c.worker, time.Second, stopCh)
 	}
+	<-stopCh
 }
 
-func (c *ThirdPartyAnalyticsController) track(objName, action, namespace string) error {
-	// TODO: All of these values/keys need to come from config
-	tracker := NewAnalyticsTracker()
-	params := map[string]string{
-		"host":                 "dev.openshift.redhat.com",
-		"event":                fmt.Sprintf("%s_%s", strings.ToLower(objName), strings.ToLower(action)),
-		"cv_email":             namespace,
-		"cv_project_namespace": namespace,
+// trackedEventFor builds the TrackedEvent an AnalyticsTracker sees from the flat queue key e.
+func trackedEventFor(e analyticsEvent) TrackedEvent {
+	return TrackedEvent{
+		Kind:   e.objectName,
+		Action: e.action,
+		Params: map[string]string{
+			"event":                fmt.Sprintf("%s_%s", strings.ToLower(e.objectName), strings.ToLower(e.action)),
+			"cv_email":             e.namespace,
+			"cv_project_namespace": e.namespace,
+		},
+		Object: cloudevents.ObjectContext{
+			Name:            e.name,
+			Namespace:       e.namespace,
+			UID:             e.uid,
+			ResourceVersion: e.resourceVersion,
+		},
 	}
+}
 
-	if err := tracker.TrackEvent(params, "GET", "http://www.woopra.com/track/ce?%s"); err != nil {
-		return fmt.Errorf("Error sending track event: %v", err)
+// sendBatch groups events by the backends each one matched at enqueue time and makes one
+// TrackBatch call per backend, rather than one TrackEvent call per event, so a backend with a
+// bulk API only sees a single HTTP request for the whole batch. Backends are sent to
+// concurrently so one slow or unreachable backend can't hold up settling events bound for the
+// others. It returns the error message(s) accumulated against each event that failed on at
+// least one backend.
+//
+// A backend that rejects its TrackBatch call has every event routed to it blamed equally, even
+// if only one event in the batch was actually at fault; the bad event is then re-batched with a
+// different set of events on retry, repeating the blame until it's retried away or dead-lettered.
+// This is the accepted cost of sending one bulk request per backend instead of one request per
+// event; a backend wanting per-event pass/fail within a batch needs a TrackBatch implementation
+// that inspects its own bulk API's per-item response, not a change here.
+func (c *ThirdPartyAnalyticsController) sendBatch(events []analyticsEvent) map[analyticsEvent][]string {
+	byBackend := map[string][]analyticsEvent{}
+	for _, e := range events {
+		for _, ref := range strings.Split(e.backends, ",") {
+			byBackend[ref] = append(byBackend[ref], e)
+		}
+	}
+
+	var mu sync.Mutex
+	errs := map[analyticsEvent][]string{}
+	record := func(refEvents []analyticsEvent, msg string) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, e := range refEvents {
+			errs[e] = append(errs[e], msg)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for ref, refEvents := range byBackend {
+		ref, refEvents := ref, refEvents
+		tracker, ok := c.trackers[ref]
+		if !ok {
+			record(refEvents, fmt.Sprintf("no backend named %q configured", ref))
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tracked := make([]TrackedEvent, len(refEvents))
+			for i, e := range refEvents {
+				tracked[i] = trackedEventFor(e)
+			}
+			if err := tracker.TrackBatch(tracked); err != nil {
+				record(refEvents, err.Error())
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// trackBatch sends batch to its matched backends and then settles every item with the queue:
+// Done always, Forget on success, handleErr (retry or dead-letter) on failure. Grouping by
+// backend before sending means a single bad item can't be blamed for another's backend's
+// failure; each event is judged only by the backends it actually matched.
+func (c *ThirdPartyAnalyticsController) trackBatch(batch []interface{}) {
+	events := make([]analyticsEvent, 0, len(batch))
+	for _, obj := range batch {
+		e, ok := obj.(analyticsEvent)
+		if !ok {
+			c.queue.Done(obj)
+			c.queue.Forget(obj)
+			continue
+		}
+		events = append(events, e)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	errs := c.sendBatch(events)
+	for _, e := range events {
+		c.queue.Done(e)
+		if msgs := errs[e]; len(msgs) > 0 {
+			c.handleErr(fmt.Errorf("error sending track event to %d backend(s): %s", len(msgs), strings.Join(msgs, "; ")), e)
+			continue
+		}
+		c.queue.Forget(e)
 	}
-	return nil
 }
 
-// worker runs a worker thread that just dequeues items, processes them, and marks them done.
+// worker runs a worker goroutine that batches queued events up to maxBatchSize or, once the
+// oldest item in the batch has waited maxBatchInterval, flushes early with however many it has.
+// Batching cuts the number of HTTP requests a bulk-capable backend sees from one per event to
+// one per batch.
 func (c *ThirdPartyAnalyticsController) worker() {
-	for {
-		func() {
+	items := make(chan interface{})
+	go func() {
+		defer close(items)
+		for {
 			obj, quit := c.queue.Get()
 			if quit {
 				return
 			}
-			defer c.queue.Done(obj)
+			items <- obj
+		}
+	}()
 
-			if e, ok := obj.(*analyticsEvent); ok {
-				err := c.track(e.objectName, e.action, e.namespace)
-				if err != nil {
-					glog.Errorf("Error tracking event: %s %s %s %v", e.objectName, e.action, e.namespace, err)
-				}
+	for {
+		batch, ok := c.collectBatch(items)
+		if len(batch) > 0 {
+			c.trackBatch(batch)
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// collectBatch accumulates items from the queue-forwarding channel ch into a batch of up to
+// maxBatchSize, flushing early once maxBatchInterval has elapsed since the first item in the
+// batch arrived. ok is false once ch is closed, meaning the queue is shutting down and no
+// further batches should be collected.
+func (c *ThirdPartyAnalyticsController) collectBatch(ch <-chan interface{}) (batch []interface{}, ok bool) {
+	first, open := <-ch
+	if !open {
+		return nil, false
+	}
+	batch = append(batch, first)
+
+	timer := time.NewTimer(c.maxBatchInterval)
+	defer timer.Stop()
+	for len(batch) < c.maxBatchSize {
+		select {
+		case obj, open := <-ch:
+			if !open {
+				return batch, false
 			}
-		}()
+			batch = append(batch, obj)
+		case <-timer.C:
+			return batch, true
+		}
+	}
+	return batch, true
+}
+
+// handleErr decides whether a failed event is retried with exponential backoff or, once it
+// has been retried maxTrackRetries times, handed to the dead-letter sink so a permanently
+// failing backend doesn't spin the worker forever.
+func (c *ThirdPartyAnalyticsController) handleErr(err error, e analyticsEvent) {
+	if c.queue.NumRequeues(e) < maxTrackRetries {
+		glog.V(4).Infof("Error tracking event %s %s %s, retrying: %v", e.objectName, e.action, e.namespace, err)
+		c.queue.AddRateLimited(e)
+		return
+	}
+
+	glog.Errorf("Giving up tracking event %s %s %s after %d retries: %v", e.objectName, e.action, e.namespace, maxTrackRetries, err)
+	c.queue.Forget(e)
+	if deadLetterErr := c.deadLetter.Send(e, err); deadLetterErr != nil {
+		glog.Errorf("Error writing event %s %s %s to dead-letter sink: %v", e.objectName, e.action, e.namespace, deadLetterErr)
 	}
 }
 
+// AnalyticsTracker sends tracking events to a third-party analytics backend, singly or batched.
 type AnalyticsTracker interface {
-	SaveEvent(objName, action, namespace string) error
+	TrackEvent(event TrackedEvent) error
+	// TrackBatch sends events in as few HTTP requests as the backend's API allows. A backend
+	// with no bulk API may simply loop over TrackEvent.
+	TrackBatch(events []TrackedEvent) error
 }
 
-func NewAnalyticsTracker() *realAnalyticsTracker {
-	return &realAnalyticsTracker{}
+// TrackedEvent is what an AnalyticsTracker receives, singly through TrackEvent or in a slice
+// through TrackBatch (the only one of the two the controller itself calls). Params is the flat
+// key/value payload the legacy form/JSON backends expect; Object carries the richer object
+// identity a CloudEvents-format backend needs but a flat params map can't.
+type TrackedEvent struct {
+	Kind   string
+	Action string
+	Params map[string]string
+	Object cloudevents.ObjectContext
 }
 
-type realAnalyticsTracker struct {
+// formatCloudEvents selects CloudEvents v1.0 JSON delivery for a BackendConfig; the zero value
+// ("") keeps the legacy flat form/JSON payload so existing configs are unaffected.
+const formatCloudEvents = "cloudevents"
+
+// BackendConfig configures one analytics backend to send events to. Endpoint, Credentials,
+// and HostID are backend-specific; FieldMapping lets operators rename the "event",
+// "cv_email", "cv_project_namespace" keys the controller produces into whatever field names
+// the destination backend expects, without recompiling the controller.
+type BackendConfig struct {
+	// Name identifies this backend as an AnalyticsPolicyRule.BackendRef. Defaults to Type if
+	// unset, so a config with a single backend of each type need not set it explicitly.
+	Name string `json:"name,omitempty"`
+	// Type selects the registered AnalyticsTrackerFactory, e.g. "woopra", "segment",
+	// "mixpanel", "generic-webhook".
+	Type string `json:"type"`
+	// Format selects the wire payload: "" (the default) sends the legacy flat form/JSON
+	// payload; "cloudevents" sends a CloudEvents v1.0 JSON envelope with full object identity.
+	// Ignored by the woopra backend, which is always the legacy query-string GET.
+	Format string `json:"format,omitempty"`
+	// Endpoint is the base URL the backend posts/gets events to.
+	Endpoint string `json:"endpoint"`
+	// BatchEndpoint is the URL a TrackBatch call posts to, for backends whose bulk API lives at
+	// a different path than Endpoint. Defaults to Endpoint for backends that accept a JSON
+	// array of events at the same URL as a single event.
+	BatchEndpoint string `json:"batchEndpoint,omitempty"`
+	// Method is the HTTP verb used to send events. Defaults to the backend's own preference.
+	// Ignored by the woopra backend, which always sends GET.
+	Method string `json:"method,omitempty"`
+	// HostID is the Woopra-style domain or app identifier some backends require.
+	HostID string `json:"hostId,omitempty"`
+	// Credentials authenticates against the backend, e.g. a Segment write key.
+	Credentials string `json:"credentials,omitempty"`
+	// ClusterID identifies the cluster an event came from. Used as the CloudEvents "source"
+	// field when Format is "cloudevents"; ignored otherwise.
+	ClusterID string `json:"clusterId,omitempty"`
+	// Headers are added to every outgoing request to this backend.
+	Headers map[string]string `json:"headers,omitempty"`
+	// FieldMapping renames event keys before they are sent to this backend.
+	FieldMapping map[string]string `json:"fieldMapping,omitempty"`
 }
 
-func (c *realAnalyticsTracker) TrackEvent(params map[string]string, method, endpoint string) error {
-	urlParams := url.Values{}
+// AnalyticsConfig selects which backends ThirdPartyAnalyticsController reports events to.
+type AnalyticsConfig struct {
+	Backends []BackendConfig `json:"backends"`
+	// DeadLetter configures where events go once they exceed maxTrackRetries. Defaults to
+	// logging the drop.
+	DeadLetter DeadLetterConfig `json:"deadLetter,omitempty"`
+	// MaxBatchSize caps how many events are flushed to a tracker in one TrackBatch call.
+	// Defaults to defaultMaxBatchSize.
+	MaxBatchSize int `json:"maxBatchSize,omitempty"`
+	// MaxBatchInterval caps how long a partial batch waits before it is flushed anyway.
+	// Defaults to defaultMaxBatchInterval.
+	MaxBatchInterval unversioned.Duration `json:"maxBatchInterval,omitempty"`
+}
+
+// AnalyticsTrackerFactory constructs an AnalyticsTracker from its BackendConfig.
+type AnalyticsTrackerFactory func(config BackendConfig) (AnalyticsTracker, error)
+
+var analyticsTrackerFactories = map[string]AnalyticsTrackerFactory{}
+
+// RegisterAnalyticsTracker makes an AnalyticsTrackerFactory available under name for use in
+// AnalyticsConfig.Backends[].Type. Intended to be called from init() by backend
+// implementations, the same way storage/volume plugins register themselves.
+func RegisterAnalyticsTracker(name string, factory AnalyticsTrackerFactory) {
+	analyticsTrackerFactories[name] = factory
+}
+
+func init() {
+	RegisterAnalyticsTracker("woopra", newWoopraTracker)
+	RegisterAnalyticsTracker("segment", newSegmentTracker)
+	RegisterAnalyticsTracker("mixpanel", newMixpanelTracker)
+	RegisterAnalyticsTracker("generic-webhook", newGenericWebhookTracker)
+}
+
+// newAnalyticsTrackers builds every configured backend, keyed by BackendConfig.Name so
+// AnalyticsPolicyRule.BackendRef can address them.
+func newAnalyticsTrackers(config AnalyticsConfig) (map[string]AnalyticsTracker, error) {
+	trackers := make(map[string]AnalyticsTracker, len(config.Backends))
+	for _, backendConfig := range config.Backends {
+		factory, ok := analyticsTrackerFactories[backendConfig.Type]
+		if !ok {
+			return nil, fmt.Errorf("no analytics tracker registered for backend type %q", backendConfig.Type)
+		}
+		tracker, err := factory(backendConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to construct %q analytics tracker: %v", backendConfig.Type, err)
+		}
+		name := backendConfig.Name
+		if len(name) == 0 {
+			name = backendConfig.Type
+		}
+		if strings.Contains(name, ",") {
+			return nil, fmt.Errorf("analytics backend name %q must not contain a comma", name)
+		}
+		if _, exists := trackers[name]; exists {
+			return nil, fmt.Errorf("duplicate analytics backend name %q", name)
+		}
+		trackers[name] = tracker
+	}
+	return trackers, nil
+}
+
+// mapFields applies config.FieldMapping to params, renaming any key that has a mapping.
+func mapFields(params map[string]string, config BackendConfig) map[string]string {
+	if len(config.FieldMapping) == 0 {
+		return params
+	}
+	mapped := make(map[string]string, len(params))
 	for key, value := range params {
+		if renamed, ok := config.FieldMapping[key]; ok {
+			key = renamed
+		}
+		mapped[key] = value
+	}
+	return mapped
+}
+
+// woopraTracker reports events to a Woopra-compatible tracking endpoint over HTTP GET, the
+// behavior this controller originally hardcoded.
+type woopraTracker struct {
+	config BackendConfig
+}
+
+func newWoopraTracker(config BackendConfig) (AnalyticsTracker, error) {
+	if len(config.Endpoint) == 0 {
+		config.Endpoint = "http://www.woopra.com/track/ce"
+	}
+	if len(config.HostID) == 0 {
+		config.HostID = "dev.openshift.redhat.com"
+	}
+	return &woopraTracker{config: config}, nil
+}
+
+func (t *woopraTracker) TrackEvent(event TrackedEvent) error {
+	urlParams := url.Values{}
+	for key, value := range mapFields(event.Params, t.config) {
 		urlParams.Add(key, value)
 	}
-	encodedUrl := urlParams.Encode()
-	glog.V(3).Infof("Tracking data: %s", encodedUrl)
-	if method == "GET" {
-		resp, err := http.Get(fmt.Sprintf(endpoint, encodedUrl))
-		//	req.SetBasicAuth(AppID, SecretKey)
-		if err != nil {
-			return err
+	urlParams.Add("host", t.config.HostID)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s?%s", t.config.Endpoint, urlParams.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	for key, value := range t.config.Headers {
+		req.Header.Set(key, value)
+	}
+	if len(t.config.Credentials) > 0 {
+		req.SetBasicAuth(t.config.HostID, t.config.Credentials)
+	}
+
+	glog.V(3).Infof("Tracking data to woopra: %s", req.URL)
+	resp, err := trackingHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if _, err := ioutil.ReadAll(resp.Body); err != nil {
+		return fmt.Errorf("error tracking event: %v", err)
+	}
+	return nil
+}
+
+// TrackBatch falls back to one TrackEvent GET per event: Woopra has no bulk API, but the shared
+// trackingHTTPClient still keeps the underlying connection alive across the batch.
+func (t *woopraTracker) TrackBatch(events []TrackedEvent) error {
+	return trackBatchByLooping(events, t.TrackEvent)
+}
+
+// segmentTracker reports events to the Segment tracking API.
+type segmentTracker struct {
+	config BackendConfig
+}
+
+func newSegmentTracker(config BackendConfig) (AnalyticsTracker, error) {
+	if len(config.Endpoint) == 0 {
+		config.Endpoint = "https://api.segment.io/v1/track"
+	}
+	if len(config.BatchEndpoint) == 0 {
+		config.BatchEndpoint = "https://api.segment.io/v1/batch"
+	}
+	if err := validateFormat(config); err != nil {
+		return nil, err
+	}
+	return &segmentTracker{config: config}, nil
+}
+
+func (t *segmentTracker) TrackEvent(event TrackedEvent) error {
+	return trackJSONEvent(t.config, event)
+}
+
+func (t *segmentTracker) TrackBatch(events []TrackedEvent) error {
+	return trackJSONBatch(t.config, events)
+}
+
+// mixpanelTracker reports events to the Mixpanel tracking API.
+type mixpanelTracker struct {
+	config BackendConfig
+}
+
+func newMixpanelTracker(config BackendConfig) (AnalyticsTracker, error) {
+	if len(config.Endpoint) == 0 {
+		config.Endpoint = "https://api.mixpanel.com/track"
+	}
+	if err := validateFormat(config); err != nil {
+		return nil, err
+	}
+	return &mixpanelTracker{config: config}, nil
+}
+
+func (t *mixpanelTracker) TrackEvent(event TrackedEvent) error {
+	return trackJSONEvent(t.config, event)
+}
+
+// TrackBatch falls back to one TrackEvent call per event: Mixpanel's import API needs per-event
+// auth query parameters this controller doesn't build, so batching here only saves round trips
+// via the shared trackingHTTPClient's keep-alive connections, not request count.
+func (t *mixpanelTracker) TrackBatch(events []TrackedEvent) error {
+	return trackBatchByLooping(events, t.TrackEvent)
+}
+
+// genericWebhookTracker posts events as JSON (one object per event from TrackEvent, a JSON array
+// per batch from TrackBatch) to an arbitrary operator-supplied endpoint, for analytics backends
+// origin has no dedicated integration for.
+type genericWebhookTracker struct {
+	config BackendConfig
+}
+
+func newGenericWebhookTracker(config BackendConfig) (AnalyticsTracker, error) {
+	if len(config.Endpoint) == 0 {
+		return nil, fmt.Errorf("generic-webhook backend requires an endpoint")
+	}
+	if err := validateFormat(config); err != nil {
+		return nil, err
+	}
+	return &genericWebhookTracker{config: config}, nil
+}
+
+func (t *genericWebhookTracker) TrackEvent(event TrackedEvent) error {
+	return trackJSONEvent(t.config, event)
+}
+
+func (t *genericWebhookTracker) TrackBatch(events []TrackedEvent) error {
+	return trackJSONBatch(t.config, events)
+}
+
+// validateFormat rejects a BackendConfig.Format this controller doesn't recognize, and requires
+// ClusterID when cloudevents format is selected since it becomes the envelope's "source" field,
+// which CloudEvents v1.0 requires to be non-empty.
+func validateFormat(config BackendConfig) error {
+	switch config.Format {
+	case "", formatCloudEvents:
+	default:
+		return fmt.Errorf("unknown backend format %q", config.Format)
+	}
+	if config.Format == formatCloudEvents && len(config.ClusterID) == 0 {
+		return fmt.Errorf("backend format %q requires clusterId to be set", formatCloudEvents)
+	}
+	return nil
+}
+
+// trackBatchByLooping is the TrackBatch fallback for backends with no bulk API: it sends each
+// event individually through trackEvent, aggregating any failures into a single error.
+func trackBatchByLooping(events []TrackedEvent, trackEvent func(TrackedEvent) error) error {
+	var errs []string
+	for _, event := range events {
+		if err := trackEvent(event); err != nil {
+			errs = append(errs, err.Error())
 		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error tracking %d of %d event(s): %s", len(errs), len(events), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// trackJSONEvent sends event using the wire format selected by config.Format: the legacy flat
+// JSON payload by default, or a CloudEvents v1.0 envelope when Format is "cloudevents".
+func trackJSONEvent(config BackendConfig, event TrackedEvent) error {
+	if config.Format == formatCloudEvents {
+		return postCloudEvent(config, event)
+	}
+	return postJSONEvent(config, mapFields(event.Params, config))
+}
 
-		_, err = ioutil.ReadAll(resp.Body)
+// trackJSONBatch sends events in a single request to batchEndpoint(config), using the wire
+// format selected by config.Format: a JSON array of the legacy flat payloads by default, or a
+// CloudEvents v1.0 "batched content mode" JSON array when Format is "cloudevents".
+func trackJSONBatch(config BackendConfig, events []TrackedEvent) error {
+	if config.Format == formatCloudEvents {
+		return postCloudEventBatch(config, events)
+	}
+	return postJSONEventBatch(config, events)
+}
+
+// batchEndpoint is where a TrackBatch call posts to: config.BatchEndpoint if set, otherwise
+// config.Endpoint, for backends whose bulk API accepts a JSON array at the same URL as a
+// single event.
+func batchEndpoint(config BackendConfig) string {
+	if len(config.BatchEndpoint) > 0 {
+		return config.BatchEndpoint
+	}
+	return config.Endpoint
+}
+
+// postJSONEventBatch POSTs events as a JSON array of the legacy flat payloads to
+// batchEndpoint(config).
+func postJSONEventBatch(config BackendConfig, events []TrackedEvent) error {
+	batch := make([]map[string]string, len(events))
+	for i, event := range events {
+		batch[i] = mapFields(event.Params, config)
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	glog.V(3).Infof("Tracking %d event(s) to %s: %s", len(events), batchEndpoint(config), body)
+	return sendTrackingRequestTo(config, batchEndpoint(config), "application/json", body)
+}
+
+// postCloudEventBatch POSTs events as a CloudEvents v1.0 "batched content mode" JSON array to
+// batchEndpoint(config).
+func postCloudEventBatch(config BackendConfig, events []TrackedEvent) error {
+	batch := make([]cloudevents.Event, len(events))
+	for i, event := range events {
+		ce, err := cloudevents.NewEvent(event.Kind, event.Action, config.ClusterID, event.Object, time.Now())
 		if err != nil {
-			return fmt.Errorf("error tracking event: %v", err)
+			return err
 		}
+		batch[i] = ce
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	glog.V(3).Infof("Tracking %d CloudEvent(s) to %s: %s", len(events), batchEndpoint(config), body)
+	return sendTrackingRequestTo(config, batchEndpoint(config), "application/cloudevents-batch+json", body)
+}
+
+// postJSONEvent POSTs params as a JSON object to config.Endpoint, used by the backends whose
+// APIs expect a JSON body rather than Woopra's query-string GET.
+func postJSONEvent(config BackendConfig, params map[string]string) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	glog.V(3).Infof("Tracking data to %s: %s", config.Endpoint, body)
+	return sendTrackingRequest(config, "application/json", body)
+}
+
+// postCloudEvent POSTs event as a CloudEvents v1.0 JSON envelope to config.Endpoint, used by
+// backends configured with Format "cloudevents" in place of the legacy flat JSON payload.
+func postCloudEvent(config BackendConfig, event TrackedEvent) error {
+	ce, err := cloudevents.NewEvent(event.Kind, event.Action, config.ClusterID, event.Object, time.Now())
+	if err != nil {
+		return err
+	}
+	body, err := cloudevents.Marshal(ce)
+	if err != nil {
+		return err
+	}
+	glog.V(3).Infof("Tracking CloudEvent to %s: %s", config.Endpoint, body)
+	return sendTrackingRequest(config, "application/cloudevents+json", body)
+}
+
+// trackingHTTPClient is shared by every backend that speaks HTTP, instead of each call using
+// http.DefaultClient, so repeated requests to the same backend (including the bursts a batch
+// flush produces) reuse a keep-alive connection rather than dialing fresh each time.
+var trackingHTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+}
+
+// sendTrackingRequest POSTs (or, per config.Method, sends) body to config.Endpoint with the
+// headers and credentials every JSON-speaking backend shares; contentType distinguishes the
+// legacy flat JSON payload from a CloudEvents envelope.
+func sendTrackingRequest(config BackendConfig, contentType string, body []byte) error {
+	return sendTrackingRequestTo(config, config.Endpoint, contentType, body)
+}
+
+// sendTrackingRequestTo is sendTrackingRequest generalized to an explicit endpoint, so a
+// TrackBatch call can post to batchEndpoint(config) instead of config.Endpoint.
+func sendTrackingRequestTo(config BackendConfig, endpoint, contentType string, body []byte) error {
+	method := config.Method
+	if len(method) == 0 {
+		method = "POST"
+	}
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for key, value := range config.Headers {
+		req.Header.Set(key, value)
+	}
+	if len(config.Credentials) > 0 {
+		req.SetBasicAuth(config.Credentials, "")
+	}
+
+	resp, err := trackingHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if _, err := ioutil.ReadAll(resp.Body); err != nil {
+		return fmt.Errorf("error tracking event: %v", err)
 	}
 	return nil
 }
 
+// analyticsEvent is a value type (rather than a pointer) so that repeated retries of the same
+// logical event share a single workqueue/rate-limiter key instead of each being treated as a
+// brand new item. backends is a sorted, comma-joined list of backend names so it stays part of
+// that comparable key rather than requiring a []string, which workqueue items can't be. name,
+// uid, and resourceVersion identify the object a CloudEvents-format backend reports on.
 type analyticsEvent struct {
-	objectName string
-	action     string
-	namespace  string
+	objectName      string
+	action          string
+	namespace       string
+	name            string
+	uid             string
+	resourceVersion string
+	backends        string
+}
+
+func newEvent(objName, action, namespace, name, uid, resourceVersion string, backends []string) analyticsEvent {
+	sorted := append([]string(nil), backends...)
+	sort.Strings(sorted)
+	return analyticsEvent{objName, action, namespace, name, uid, resourceVersion, strings.Join(sorted, ",")}
+}
+
+// DeadLetterSink records tracking events that failed to send after maxTrackRetries attempts,
+// so a permanent backend outage leaves a record of what was dropped instead of losing data
+// silently.
+type DeadLetterSink interface {
+	Send(event analyticsEvent, cause error) error
+}
+
+// DeadLetterConfig selects and configures the DeadLetterSink. Type is one of "log" (the
+// default), "file", or "http".
+type DeadLetterConfig struct {
+	Type     string `json:"type,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+func newDeadLetterSink(config DeadLetterConfig) (DeadLetterSink, error) {
+	switch config.Type {
+	case "", "log":
+		return &logDeadLetterSink{}, nil
+	case "file":
+		if len(config.Path) == 0 {
+			return nil, fmt.Errorf("file dead-letter sink requires a path")
+		}
+		return &fileDeadLetterSink{path: config.Path}, nil
+	case "http":
+		if len(config.Endpoint) == 0 {
+			return nil, fmt.Errorf("http dead-letter sink requires an endpoint")
+		}
+		return &httpDeadLetterSink{endpoint: config.Endpoint}, nil
+	default:
+		return nil, fmt.Errorf("unknown dead-letter sink type %q", config.Type)
+	}
+}
+
+// logDeadLetterSink just logs the dropped event; it is the default so dead-lettering never
+// requires extra configuration to at least be visible in the master's logs.
+type logDeadLetterSink struct{}
+
+func (s *logDeadLetterSink) Send(event analyticsEvent, cause error) error {
+	glog.Errorf("Dropping analytics event %s %s %s/%s (uid %s) after exhausting retries: %v", event.objectName, event.action, event.namespace, event.name, event.uid, cause)
+	return nil
+}
+
+// fileDeadLetterSink appends dropped events to a local file as newline-delimited JSON.
+type fileDeadLetterSink struct {
+	path string
+}
+
+func (s *fileDeadLetterSink) Send(event analyticsEvent, cause error) error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	record := struct {
+		ObjectName      string `json:"objectName"`
+		Action          string `json:"action"`
+		Namespace       string `json:"namespace"`
+		Name            string `json:"name"`
+		UID             string `json:"uid"`
+		ResourceVersion string `json:"resourceVersion"`
+		Cause           string `json:"cause"`
+	}{event.objectName, event.action, event.namespace, event.name, event.uid, event.resourceVersion, cause.Error()}
+
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(body, '\n'))
+	return err
 }
 
-func newEvent(objName, action, namespace string) *analyticsEvent {
-	return &analyticsEvent{objName, action, namespace}
+// httpDeadLetterSink POSTs dropped events to an operator-supplied HTTP endpoint.
+type httpDeadLetterSink struct {
+	endpoint string
+}
+
+func (s *httpDeadLetterSink) Send(event analyticsEvent, cause error) error {
+	record := struct {
+		ObjectName      string `json:"objectName"`
+		Action          string `json:"action"`
+		Namespace       string `json:"namespace"`
+		Name            string `json:"name"`
+		UID             string `json:"uid"`
+		ResourceVersion string `json:"resourceVersion"`
+		Cause           string `json:"cause"`
+	}{event.objectName, event.action, event.namespace, event.name, event.uid, event.resourceVersion, cause.Error()}
+
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := trackingHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if _, err := ioutil.ReadAll(resp.Body); err != nil {
+		return fmt.Errorf("error sending event to dead-letter sink: %v", err)
+	}
+	return nil
 }